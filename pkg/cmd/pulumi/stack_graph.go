@@ -17,6 +17,7 @@ package main
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 
 	"github.com/pulumi/pulumi/pkg/v3/backend/display"
@@ -207,11 +208,24 @@ type dependencyGraph struct {
 	vertices map[resource.URN]*dependencyVertex
 }
 
+// sortedVertices returns the graph's vertices ordered by URN. Iterating in this order rather than over
+// the map keeps the root set and every vertex's edge lists in the same order from run to run.
+func (dg *dependencyGraph) sortedVertices() []*dependencyVertex {
+	vertices := make([]*dependencyVertex, 0, len(dg.vertices))
+	for _, vertex := range dg.vertices {
+		vertices = append(vertices, vertex)
+	}
+	sort.Slice(vertices, func(i, j int) bool {
+		return vertices[i].resource.URN < vertices[j].resource.URN
+	})
+	return vertices
+}
+
 // Roots are edges that point to the root set of our graph. In our case,
 // for simplicity, we define the root set of our dependency graph to be everything.
 func (dg *dependencyGraph) Roots() []graph.Edge {
 	rootEdges := []graph.Edge{}
-	for _, vertex := range dg.vertices {
+	for _, vertex := range dg.sortedVertices() {
 		edge := &dependencyEdge{
 			to:   vertex,
 			from: nil,
@@ -239,7 +253,7 @@ func makeDependencyGraph(snapshot *deploy.Snapshot) *dependencyGraph {
 		dg.vertices[resource.URN] = vertex
 	}
 
-	for _, vertex := range dg.vertices {
+	for _, vertex := range dg.sortedVertices() {
 		if !ignoreDependencyEdges {
 			// If we have per-property dependency information, annotate the dependency edges
 			// we generate with the names of the properties associated with each dependency.
@@ -249,6 +263,9 @@ func makeDependencyGraph(snapshot *deploy.Snapshot) *dependencyGraph {
 					depBlame[dep] = append(depBlame[dep], string(k))
 				}
 			}
+			for _, props := range depBlame {
+				sort.Strings(props)
+			}
 
 			// Incoming edges are directly stored within the checkpoint file; they represent
 			// resources on which this vertex immediately depends upon.
@@ -0,0 +1,82 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/pulumi/pulumi/pkg/v3/graph/dotconv"
+	"github.com/pulumi/pulumi/pkg/v3/resource/deploy"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/resource"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/tokens"
+)
+
+// Ensures the dependency graph comes out in the same order every time it is built, rather than in map order.
+func TestDependencyGraphIsDeterministic(t *testing.T) {
+	t.Parallel()
+
+	urn := func(name string) resource.URN {
+		return resource.NewURN("test", "test", "", "pkg:index:Thing", tokens.QName(name))
+	}
+	a, b, c, d := urn("a"), urn("b"), urn("c"), urn("d")
+
+	// Listed out of URN order: c depends on a and b, b depends on a, and d is a child of a.
+	snap := &deploy.Snapshot{
+		Resources: []*resource.State{
+			{URN: d, Parent: a},
+			{
+				URN:          c,
+				Dependencies: []resource.URN{a, b},
+				PropertyDependencies: map[resource.PropertyKey][]resource.URN{
+					"z": {a},
+					"y": {a, b},
+				},
+			},
+			{URN: a},
+			{URN: b, Dependencies: []resource.URN{a}},
+		},
+	}
+
+	expected := fmt.Sprintf(`strict digraph {
+    Resource0 [label="%s"];
+    Resource0 -> Resource1;
+    Resource0 -> Resource2 [label = "y, z"];
+    Resource1 [label="%s"];
+    Resource1 -> Resource2 [label = "y"];
+    Resource2 [label="%s"];
+    Resource3 [label="%s"];
+    Resource3 -> Resource0;
+}
+`, a, b, c, d)
+
+	for i := 0; i < 20; i++ {
+		dg := makeDependencyGraph(snap)
+
+		var roots []resource.URN
+		for _, root := range dg.Roots() {
+			roots = append(roots, root.To().(*dependencyVertex).resource.URN)
+		}
+		assert.Equal(t, []resource.URN{a, b, c, d}, roots)
+
+		var buf bytes.Buffer
+		require.NoError(t, dotconv.Print(dg, &buf))
+		assert.Equal(t, expected, buf.String())
+	}
+}
@@ -0,0 +1,115 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"fmt"
+)
+
+// GraphDiff describes how one graph differs from another.  Vertices and edges that only exist in the old graph are
+// taken from it; everything else is taken from the new graph.  Each list is in the order in which Walk visits the
+// graph it was taken from.
+type GraphDiff struct {
+	AddedVertices     []Vertex // vertices present only in the new graph.
+	RemovedVertices   []Vertex // vertices present only in the old graph.
+	UnchangedVertices []Vertex // vertices present in both graphs.
+	AddedEdges        []Edge   // edges present only in the new graph.
+	RemovedEdges      []Edge   // edges present only in the old graph.
+}
+
+// edgeKey identifies an edge by the keys of the vertices at either end.
+type edgeKey struct {
+	from string
+	to   string
+}
+
+// keyedEdge is an edge along with its key, computed once during the walk so it needn't be recomputed when comparing.
+type keyedEdge struct {
+	edge Edge
+	key  edgeKey
+}
+
+// keyedGraph is a graph's reachable vertices and edges, indexed by key and kept in walk order.
+type keyedGraph struct {
+	vertices  []Vertex
+	vertexSet map[string]bool
+	edges     []keyedEdge
+	edgeSet   map[edgeKey]bool
+}
+
+func newKeyedGraph(g Graph, key func(Vertex) string) (*keyedGraph, error) {
+	kg := &keyedGraph{vertexSet: make(map[string]bool), edgeSet: make(map[edgeKey]bool)}
+	err := Walk(g, func(v Vertex) error {
+		k := key(v)
+		if kg.vertexSet[k] {
+			return fmt.Errorf("multiple vertices have the key %q", k)
+		}
+		kg.vertexSet[k] = true
+		kg.vertices = append(kg.vertices, v)
+
+		for _, out := range v.Outs() {
+			// Parallel edges between the same pair of vertices are indistinguishable by key, so keep the first.
+			if ek := (edgeKey{from: k, to: key(out.To())}); !kg.edgeSet[ek] {
+				kg.edgeSet[ek] = true
+				kg.edges = append(kg.edges, keyedEdge{edge: out, key: ek})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return kg, nil
+}
+
+// Diff compares the vertices and edges reachable from the roots of two graphs.  Vertices are matched by the string
+// that key returns for them, rather than by identity, so that graphs built separately (e.g. from two snapshots) can be
+// compared; key must therefore be unique within each graph.  Edges are matched by the keys of their endpoints, so a
+// change to only an edge's label or color is not reported.
+func Diff(olds, news Graph, key func(Vertex) string) (*GraphDiff, error) {
+	o, err := newKeyedGraph(olds, key)
+	if err != nil {
+		return nil, fmt.Errorf("old graph: %w", err)
+	}
+	n, err := newKeyedGraph(news, key)
+	if err != nil {
+		return nil, fmt.Errorf("new graph: %w", err)
+	}
+
+	var diff GraphDiff
+	for _, v := range n.vertices {
+		if o.vertexSet[key(v)] {
+			diff.UnchangedVertices = append(diff.UnchangedVertices, v)
+		} else {
+			diff.AddedVertices = append(diff.AddedVertices, v)
+		}
+	}
+	for _, v := range o.vertices {
+		if !n.vertexSet[key(v)] {
+			diff.RemovedVertices = append(diff.RemovedVertices, v)
+		}
+	}
+	for _, e := range n.edges {
+		if !o.edgeSet[e.key] {
+			diff.AddedEdges = append(diff.AddedEdges, e.edge)
+		}
+	}
+	for _, e := range o.edges {
+		if !n.edgeSet[e.key] {
+			diff.RemovedEdges = append(diff.RemovedEdges, e.edge)
+		}
+	}
+	return &diff, nil
+}
@@ -0,0 +1,68 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v3/graph"
+	"github.com/pulumi/pulumi/pkg/v3/graph/internal/graphtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func byLabel(v graph.Vertex) string {
+	return v.Label()
+}
+
+func edgeLabels(es []graph.Edge) []string {
+	var ls []string
+	for _, e := range es {
+		ls = append(ls, e.From().Label()+"->"+e.To().Label())
+	}
+	return ls
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	// Old: app -> db -> net.
+	oldApp, oldDB, oldNet := graphtest.NewVertex("app"), graphtest.NewVertex("db"), graphtest.NewVertex("net")
+	graphtest.Connect(oldApp, oldDB, "", "")
+	graphtest.Connect(oldDB, oldNet, "", "")
+	olds := graphtest.NewGraph(oldApp, oldDB, oldNet)
+
+	// New: app -> db, app -> cache.  The vertices are distinct objects from the old graph's.
+	newApp, newDB, newCache := graphtest.NewVertex("app"), graphtest.NewVertex("db"), graphtest.NewVertex("cache")
+	graphtest.Connect(newApp, newDB, "", "")
+	graphtest.Connect(newApp, newCache, "", "")
+	news := graphtest.NewGraph(newApp, newDB, newCache)
+
+	diff, err := graph.Diff(olds, news, byLabel)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"cache"}, labels(diff.AddedVertices))
+	assert.Equal(t, []string{"net"}, labels(diff.RemovedVertices))
+	assert.Equal(t, []string{"app", "db"}, labels(diff.UnchangedVertices))
+	assert.Equal(t, []string{"app->cache"}, edgeLabels(diff.AddedEdges))
+	assert.Equal(t, []string{"db->net"}, edgeLabels(diff.RemovedEdges))
+}
+
+func TestDiffDuplicateKey(t *testing.T) {
+	t.Parallel()
+
+	g := graphtest.NewGraph(graphtest.NewVertex("a"), graphtest.NewVertex("a"))
+	_, err := graph.Diff(g, graphtest.NewGraph(), byLabel)
+	assert.ErrorContains(t, err, `old graph: multiple vertices have the key "a"`)
+}
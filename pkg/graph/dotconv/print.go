@@ -21,12 +21,9 @@ import (
 	"bufio"
 	"fmt"
 	"io"
-	"strconv"
 	"strings"
 
 	"github.com/pulumi/pulumi/pkg/v3/graph"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/slice"
-	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
 )
 
 // Print prints a resource graph.
@@ -40,41 +37,15 @@ func Print(g graph.Graph, w io.Writer) error {
 		return err
 	}
 
-	// Initialize the frontier with unvisited graph vertices.
-	queued := make(map[graph.Vertex]bool)
-	frontier := slice.Prealloc[graph.Vertex](len(g.Roots()))
-	for _, root := range g.Roots() {
-		to := root.To()
-		queued[to] = true
-		frontier = append(frontier, to)
-	}
-
 	// For now, we auto-generate IDs.
 	// TODO[pulumi/pulumi#76]: use the object URNs instead, once we have them.
-	c := 0
-	ids := make(map[graph.Vertex]string)
-	getID := func(v graph.Vertex) string {
-		if id, has := ids[v]; has {
-			return id
-		}
-		id := "Resource" + strconv.Itoa(c)
-		c++
-		ids[v] = id
-		return id
-	}
+	var ids graph.VertexIDs
 
-	// Now, until the frontier is empty, emit entries into the stream.
+	// Now walk the graph, emitting each vertex into the stream.
 	indent := "    "
-	emitted := make(map[graph.Vertex]bool)
-	for len(frontier) > 0 {
-		// Dequeue the head of the frontier.
-		v := frontier[0]
-		frontier = frontier[1:]
-		contract.Assertf(!emitted[v], "vertex was emitted twice")
-		emitted[v] = true
-
+	err := graph.Walk(g, func(v graph.Vertex) error {
 		// Get and lazily allocate the ID for this vertex.
-		id := getID(v)
+		id := ids.ID(v)
 
 		// Print this vertex; first its "label" (type) and then its direct dependencies.
 		// IDEA: consider serializing properties on the node also.
@@ -91,39 +62,33 @@ func Print(g graph.Graph, w io.Writer) error {
 		}
 
 		// Now print out all dependencies as "ID -> {A ... Z}".
-		outs := v.Outs()
-		if len(outs) > 0 {
-			base := fmt.Sprintf("%v%v", indent, id)
-			// Print the ID of each dependency and, for those we haven't seen, add them to the frontier.
-			for _, out := range outs {
-				to := out.To()
-				if _, err := fmt.Fprintf(b, "%s -> %s", base, getID(to)); err != nil {
-					return err
-				}
-
-				var attrs []string
-				if out.Color() != "" {
-					attrs = append(attrs, fmt.Sprintf("color = \"%s\"", out.Color()))
-				}
-				if out.Label() != "" {
-					attrs = append(attrs, fmt.Sprintf("label = \"%s\"", out.Label()))
-				}
-				if len(attrs) > 0 {
-					if _, err := fmt.Fprintf(b, " [%s]", strings.Join(attrs, ", ")); err != nil {
-						return err
-					}
-				}
+		base := fmt.Sprintf("%v%v", indent, id)
+		for _, out := range v.Outs() {
+			if _, err := fmt.Fprintf(b, "%s -> %s", base, ids.ID(out.To())); err != nil {
+				return err
+			}
 
-				if _, err := b.WriteString(";\n"); err != nil {
+			var attrs []string
+			if out.Color() != "" {
+				attrs = append(attrs, fmt.Sprintf("color = \"%s\"", out.Color()))
+			}
+			if out.Label() != "" {
+				attrs = append(attrs, fmt.Sprintf("label = \"%s\"", out.Label()))
+			}
+			if len(attrs) > 0 {
+				if _, err := fmt.Fprintf(b, " [%s]", strings.Join(attrs, ", ")); err != nil {
 					return err
 				}
+			}
 
-				if _, q := queued[to]; !q {
-					queued[to] = true
-					frontier = append(frontier, to)
-				}
+			if _, err := b.WriteString(";\n"); err != nil {
+				return err
 			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
 
 	// Finish the graph.
@@ -0,0 +1,66 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dotconv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v3/graph/internal/graphtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrint(t *testing.T) {
+	t.Parallel()
+
+	// a -> b (colored and labeled), a -> c, b -> d (colored), c -> d (labeled); c has no label, and d is also a root.
+	a, b, c, d := graphtest.NewVertex("a"), graphtest.NewVertex("b"), graphtest.NewVertex(""), graphtest.NewVertex("d")
+	graphtest.Connect(a, b, "x", "red")
+	graphtest.Connect(a, c, "", "")
+	graphtest.Connect(b, d, "", "blue")
+	graphtest.Connect(c, d, "y", "")
+
+	var buf bytes.Buffer
+	require.NoError(t, Print(graphtest.NewGraph(a, d), &buf))
+	assert.Equal(t, `strict digraph {
+    Resource0 [label="a"];
+    Resource0 -> Resource1 [color = "red", label = "x"];
+    Resource0 -> Resource2;
+    Resource3 [label="d"];
+    Resource1 [label="b"];
+    Resource1 -> Resource3 [color = "blue"];
+    Resource2;
+    Resource2 -> Resource3 [label = "y"];
+}
+`, buf.String())
+}
+
+func TestPrintDuplicateRoots(t *testing.T) {
+	t.Parallel()
+
+	// A vertex listed twice among the roots is printed once.
+	a, b := graphtest.NewVertex("a"), graphtest.NewVertex("b")
+	graphtest.Connect(a, b, "", "")
+
+	var buf bytes.Buffer
+	require.NoError(t, Print(graphtest.NewGraph(a, b, a), &buf))
+	assert.Equal(t, `strict digraph {
+    Resource0 [label="a"];
+    Resource0 -> Resource1;
+    Resource1 [label="b"];
+}
+`, buf.String())
+}
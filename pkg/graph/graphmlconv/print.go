@@ -0,0 +1,115 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graphmlconv converts a resource graph into its GraphML equivalent.  This is useful for integration with
+// graph editors and analysis tools, like yEd, that consume GraphML.  Please see http://graphml.graphdrawing.org/ for
+// a specification of the GraphML file format.
+package graphmlconv
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/graph"
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// header declares the document and the data keys used by nodes and edges.
+const header = `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+    <key id="label" for="node" attr.name="label" attr.type="string"/>
+    <key id="edgeLabel" for="edge" attr.name="label" attr.type="string"/>
+    <key id="edgeColor" for="edge" attr.name="color" attr.type="string"/>
+    <graph id="G" edgedefault="directed">
+`
+
+// Print prints a resource graph as a GraphML document.  Nodes carry their label, and edges carry their label and
+// color, as GraphML data elements.
+func Print(g graph.Graph, w io.Writer) error {
+	b := bufio.NewWriter(w)
+
+	// Print the document header.
+	if _, err := b.WriteString(header); err != nil {
+		return err
+	}
+
+	// Now walk the graph, emitting each vertex into the stream.
+	var ids graph.VertexIDs
+	indent := "        "
+	err := graph.Walk(g, func(v graph.Vertex) error {
+		// Print this vertex and its label, if any.
+		id := ids.ID(v)
+		if label := v.Label(); label != "" {
+			if _, err := fmt.Fprintf(b, "%s<node id=\"%s\">\n%s    <data key=\"label\">%s</data>\n%s</node>\n",
+				indent, id, indent, escape(label), indent); err != nil {
+				return err
+			}
+		} else {
+			if _, err := fmt.Fprintf(b, "%s<node id=\"%s\"/>\n", indent, id); err != nil {
+				return err
+			}
+		}
+
+		// Now print out all dependencies as edges, carrying their labels and colors as data.
+		for _, out := range v.Outs() {
+			var data []string
+			if label := out.Label(); label != "" {
+				data = append(data, fmt.Sprintf("<data key=\"edgeLabel\">%s</data>", escape(label)))
+			}
+			if color := out.Color(); color != "" {
+				data = append(data, fmt.Sprintf("<data key=\"edgeColor\">%s</data>", escape(color)))
+			}
+
+			edge := fmt.Sprintf("<edge source=\"%s\" target=\"%s\"", id, ids.ID(out.To()))
+			if len(data) == 0 {
+				if _, err := fmt.Fprintf(b, "%s%s/>\n", indent, edge); err != nil {
+					return err
+				}
+				continue
+			}
+			if _, err := fmt.Fprintf(b, "%s%s>\n", indent, edge); err != nil {
+				return err
+			}
+			for _, d := range data {
+				if _, err := fmt.Fprintf(b, "%s    %s\n", indent, d); err != nil {
+					return err
+				}
+			}
+			if _, err := fmt.Fprintf(b, "%s</edge>\n", indent); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Finish the document.
+	if _, err := b.WriteString("    </graph>\n</graphml>\n"); err != nil {
+		return err
+	}
+	return b.Flush()
+}
+
+// escape escapes a string for use as XML character data.
+func escape(s string) string {
+	var sb strings.Builder
+	err := xml.EscapeText(&sb, []byte(s))
+	contract.AssertNoErrorf(err, "writing to a strings.Builder cannot fail")
+	return sb.String()
+}
@@ -0,0 +1,80 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graphmlconv
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v3/graph/internal/graphtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrint(t *testing.T) {
+	t.Parallel()
+
+	a := graphtest.NewVertex("web")
+	b := graphtest.NewVertex("")
+	c := graphtest.NewVertex(`vpc <"main" & co>`)
+	graphtest.Connect(a, b, "", "")
+	graphtest.Connect(a, c, "vpcId", "")
+	graphtest.Connect(b, c, "", "#246C60")
+	graphtest.Connect(c, a, "", "")
+
+	var buf bytes.Buffer
+	require.NoError(t, Print(graphtest.NewGraph(a), &buf))
+	assert.Equal(t, `<?xml version="1.0" encoding="UTF-8"?>
+<graphml xmlns="http://graphml.graphdrawing.org/xmlns">
+    <key id="label" for="node" attr.name="label" attr.type="string"/>
+    <key id="edgeLabel" for="edge" attr.name="label" attr.type="string"/>
+    <key id="edgeColor" for="edge" attr.name="color" attr.type="string"/>
+    <graph id="G" edgedefault="directed">
+        <node id="Resource0">
+            <data key="label">web</data>
+        </node>
+        <edge source="Resource0" target="Resource1"/>
+        <edge source="Resource0" target="Resource2">
+            <data key="edgeLabel">vpcId</data>
+        </edge>
+        <node id="Resource1"/>
+        <edge source="Resource1" target="Resource2">
+            <data key="edgeColor">#246C60</data>
+        </edge>
+        <node id="Resource2">
+            <data key="label">vpc &lt;&#34;main&#34; &amp; co&gt;</data>
+        </node>
+        <edge source="Resource2" target="Resource0"/>
+    </graph>
+</graphml>
+`, buf.String())
+
+	// The output must also be well-formed XML that round-trips the escaped label.
+	var doc struct {
+		Nodes []struct {
+			ID   string `xml:"id,attr"`
+			Data string `xml:"data"`
+		} `xml:"graph>node"`
+		Edges []struct {
+			Source string `xml:"source,attr"`
+			Target string `xml:"target,attr"`
+		} `xml:"graph>edge"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+	assert.Len(t, doc.Nodes, 3)
+	assert.Len(t, doc.Edges, 4)
+	assert.Equal(t, `vpc <"main" & co>`, doc.Nodes[2].Data)
+}
@@ -0,0 +1,75 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package graphtest provides a minimal in-memory implementation of the graph interfaces for use in tests.
+package graphtest
+
+import (
+	"github.com/pulumi/pulumi/pkg/v3/graph"
+)
+
+// Graph is a graph whose roots are edges to a fixed list of vertices.
+type Graph struct {
+	roots []graph.Edge
+}
+
+// NewGraph creates a graph rooted at the given vertices, in order.
+func NewGraph(roots ...*Vertex) *Graph {
+	g := &Graph{}
+	for _, r := range roots {
+		g.roots = append(g.roots, &Edge{to: r})
+	}
+	return g
+}
+
+func (g *Graph) Roots() []graph.Edge { return g.roots }
+
+// Vertex is a labeled vertex whose edges are added with Connect.
+type Vertex struct {
+	label string
+	ins   []graph.Edge
+	outs  []graph.Edge
+}
+
+// NewVertex creates a vertex with the given label and no edges.
+func NewVertex(label string) *Vertex {
+	return &Vertex{label: label}
+}
+
+func (v *Vertex) Data() interface{}  { return nil }
+func (v *Vertex) Label() string      { return v.label }
+func (v *Vertex) Ins() []graph.Edge  { return v.ins }
+func (v *Vertex) Outs() []graph.Edge { return v.outs }
+
+// Edge is an edge between two vertices with an optional label and color.
+type Edge struct {
+	label string
+	color string
+	from  *Vertex
+	to    *Vertex
+}
+
+// Connect adds an edge from one vertex to another, recording it on both ends.
+func Connect(from, to *Vertex, label, color string) *Edge {
+	e := &Edge{label: label, color: color, from: from, to: to}
+	from.outs = append(from.outs, e)
+	to.ins = append(to.ins, e)
+	return e
+}
+
+func (e *Edge) Data() interface{}  { return nil }
+func (e *Edge) Label() string      { return e.label }
+func (e *Edge) To() graph.Vertex   { return e.to }
+func (e *Edge) From() graph.Vertex { return e.from }
+func (e *Edge) Color() string      { return e.color }
@@ -0,0 +1,80 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mermaidconv converts a resource graph into its Mermaid flowchart equivalent.  This is useful for embedding
+// graphs in Markdown documents and dashboards that render Mermaid natively.  Please see
+// https://mermaid.js.org/syntax/flowchart.html for a specification of the flowchart syntax.
+package mermaidconv
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pulumi/pulumi/pkg/v3/graph"
+)
+
+// Print prints a resource graph as a Mermaid "graph TD" block, with one node per vertex and one "-->" link per edge.
+func Print(g graph.Graph, w io.Writer) error {
+	b := bufio.NewWriter(w)
+
+	// Print the graph header.
+	if _, err := b.WriteString("graph TD\n"); err != nil {
+		return err
+	}
+
+	// Now walk the graph, emitting each vertex into the stream.
+	var ids graph.VertexIDs
+	indent := "    "
+	err := graph.Walk(g, func(v graph.Vertex) error {
+		// Print this vertex, labeled if it has a label, and then its direct dependencies.
+		id := ids.ID(v)
+		if _, err := fmt.Fprintf(b, "%s%s", indent, id); err != nil {
+			return err
+		}
+		if label := v.Label(); label != "" {
+			if _, err := fmt.Fprintf(b, "[\"%s\"]", escape(label)); err != nil {
+				return err
+			}
+		}
+		if _, err := b.WriteString("\n"); err != nil {
+			return err
+		}
+
+		// Now print out all dependencies as "ID --> ID", or "ID -->|label| ID" for labeled edges.
+		for _, out := range v.Outs() {
+			arrow := "-->"
+			if label := out.Label(); label != "" {
+				arrow = fmt.Sprintf("-->|\"%s\"|", escape(label))
+			}
+			if _, err := fmt.Fprintf(b, "%s%s %s %s\n", indent, id, arrow, ids.ID(out.To())); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return b.Flush()
+}
+
+// escape replaces characters that are significant inside a quoted Mermaid label with their entity codes.  '#' must
+// be encoded first, since Mermaid reads "#...;" sequences as entity codes and we introduce such sequences ourselves.
+func escape(s string) string {
+	s = strings.ReplaceAll(s, "#", "#35;")
+	return strings.ReplaceAll(s, `"`, "#quot;")
+}
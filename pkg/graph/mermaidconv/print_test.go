@@ -0,0 +1,77 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mermaidconv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v3/graph/internal/graphtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrintChain(t *testing.T) {
+	t.Parallel()
+
+	a := graphtest.NewVertex("aws:ec2/instance:Instance::web")
+	b := graphtest.NewVertex("aws:ec2/securityGroup:SecurityGroup::group")
+	c := graphtest.NewVertex("aws:ec2/vpc:Vpc::\"main\"")
+	graphtest.Connect(a, b, "", "")
+	graphtest.Connect(b, c, "vpcId", "")
+
+	var buf bytes.Buffer
+	require.NoError(t, Print(graphtest.NewGraph(a), &buf))
+	assert.Equal(t, `graph TD
+    Resource0["aws:ec2/instance:Instance::web"]
+    Resource0 --> Resource1
+    Resource1["aws:ec2/securityGroup:SecurityGroup::group"]
+    Resource1 -->|"vpcId"| Resource2
+    Resource2["aws:ec2/vpc:Vpc::#quot;main#quot;"]
+`, buf.String())
+}
+
+func TestPrintEscapesHash(t *testing.T) {
+	t.Parallel()
+
+	a := graphtest.NewVertex("pkg:index:Thing::web#1")
+	b := graphtest.NewVertex("pkg:index:Thing::#quot;")
+	graphtest.Connect(a, b, "tags#name", "")
+
+	var buf bytes.Buffer
+	require.NoError(t, Print(graphtest.NewGraph(a), &buf))
+	assert.Equal(t, `graph TD
+    Resource0["pkg:index:Thing::web#35;1"]
+    Resource0 -->|"tags#35;name"| Resource1
+    Resource1["pkg:index:Thing::#35;quot;"]
+`, buf.String())
+}
+
+func TestPrintMultipleRoots(t *testing.T) {
+	t.Parallel()
+
+	// Two roots: a chain web -> db, and a standalone bucket.  db is also listed as a root, after bucket.
+	web, db, bucket := graphtest.NewVertex("web"), graphtest.NewVertex("db"), graphtest.NewVertex("bucket")
+	graphtest.Connect(web, db, "", "")
+
+	var buf bytes.Buffer
+	require.NoError(t, Print(graphtest.NewGraph(web, bucket, db), &buf))
+	assert.Equal(t, `graph TD
+    Resource0["web"]
+    Resource0 --> Resource1
+    Resource2["bucket"]
+    Resource1["db"]
+`, buf.String())
+}
@@ -0,0 +1,106 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"github.com/pulumi/pulumi/sdk/v3/go/common/util/contract"
+)
+
+// The helpers in this file treat a vertex's outgoing edges as pointing at the vertices it depends on, which is the
+// convention Topsort uses.  Not every graph follows it.  In particular, the stack graph built by `pulumi stack graph`
+// (pkg/cmd/pulumi/stack_graph.go) points each dependency edge from the depended-on resource to its dependent, and
+// also adds an edge from each child to its parent.  On that graph the answers are reversed: Leaves returns the
+// resources that nothing depends on, and Orphans returns those that depend on nothing and are safe to create first.
+//
+// To keep edges that aren't dependencies, like those parent edges, out of the answer, each helper takes a follow
+// func that selects the edges to consider.  A nil follow considers every edge.  Results come back in Walk order, so
+// they are deterministic only if the graph reports its root and outgoing edges in a fixed order.
+
+// Leaves returns the vertices reachable from the graph's roots that have no outgoing edges accepted by follow.  Under
+// Topsort's convention, these are the vertices that depend on nothing.  Vertices are returned in Walk order.
+func Leaves(g Graph, follow func(Edge) bool) []Vertex {
+	var leaves []Vertex
+	err := Walk(g, func(v Vertex) error {
+		if len(followed(v.Outs(), follow)) == 0 {
+			leaves = append(leaves, v)
+		}
+		return nil
+	})
+	contract.AssertNoErrorf(err, "visitor does not fail")
+	return leaves
+}
+
+// Orphans returns the vertices reachable from the graph's roots that no edge accepted by follow points at.  Under
+// Topsort's convention, these are the vertices that nothing depends on.  Incoming edges are derived from Outs rather
+// than Ins, since the stack graph records its parent edges on the child's Outs only.  Vertices are returned in Walk
+// order.
+func Orphans(g Graph, follow func(Edge) bool) []Vertex {
+	var visited []Vertex
+	targeted := make(map[Vertex]bool)
+	err := Walk(g, func(v Vertex) error {
+		visited = append(visited, v)
+		for _, out := range followed(v.Outs(), follow) {
+			targeted[out.To()] = true
+		}
+		return nil
+	})
+	contract.AssertNoErrorf(err, "visitor does not fail")
+
+	var orphans []Vertex
+	for _, v := range visited {
+		if !targeted[v] {
+			orphans = append(orphans, v)
+		}
+	}
+	return orphans
+}
+
+// Dependencies returns every vertex reachable from v by following outgoing edges accepted by follow, directly or
+// indirectly, excluding v itself.  Under Topsort's convention, these are v's transitive dependencies.  Each vertex
+// appears exactly once and, outside of cycles, after all of its own dependencies, so the result is an order in which
+// they may be created.  Unlike Topsort, cycles are tolerated: a vertex that has already been reached is not revisited,
+// which means that within a cycle at least one vertex appears before something it depends on.
+func Dependencies(v Vertex, follow func(Edge) bool) []Vertex {
+	var deps []Vertex
+	visited := map[Vertex]bool{v: true}
+	for _, out := range followed(v.Outs(), follow) {
+		depvisit(out.To(), follow, &deps, visited)
+	}
+	return deps
+}
+
+func depvisit(n Vertex, follow func(Edge) bool, deps *[]Vertex, visited map[Vertex]bool) {
+	if !visited[n] {
+		visited[n] = true
+		for _, m := range followed(n.Outs(), follow) {
+			depvisit(m.To(), follow, deps, visited)
+		}
+		*deps = append(*deps, n)
+	}
+}
+
+// followed returns the edges accepted by follow, or all of them if follow is nil.
+func followed(edges []Edge, follow func(Edge) bool) []Edge {
+	if follow == nil {
+		return edges
+	}
+	var accepted []Edge
+	for _, e := range edges {
+		if follow(e) {
+			accepted = append(accepted, e)
+		}
+	}
+	return accepted
+}
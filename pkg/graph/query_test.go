@@ -0,0 +1,112 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v3/graph"
+	"github.com/pulumi/pulumi/pkg/v3/graph/internal/graphtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLeavesAndOrphans(t *testing.T) {
+	t.Parallel()
+
+	// app -> db -> net, app -> net, worker -> db, and a lone vertex with no edges at all.
+	app, worker := graphtest.NewVertex("app"), graphtest.NewVertex("worker")
+	db, net := graphtest.NewVertex("db"), graphtest.NewVertex("net")
+	lone := graphtest.NewVertex("lone")
+	graphtest.Connect(app, db, "", "")
+	graphtest.Connect(app, net, "", "")
+	graphtest.Connect(db, net, "", "")
+	graphtest.Connect(worker, db, "", "")
+
+	// Every vertex is a root, as with the stack graph, so orphans can't be inferred from the root set alone.
+	g := graphtest.NewGraph(net, db, app, worker, lone)
+
+	assert.Equal(t, []string{"net", "lone"}, labels(graph.Leaves(g, nil)))
+	assert.Equal(t, []string{"app", "worker", "lone"}, labels(graph.Orphans(g, nil)))
+}
+
+func TestLeavesAndOrphansFollow(t *testing.T) {
+	t.Parallel()
+
+	// Shaped like the stack graph: dependency edges run from the depended-on resource to its dependent (net -> db ->
+	// app), and a parent edge runs from the child app to its parent comp.
+	comp, app := graphtest.NewVertex("comp"), graphtest.NewVertex("app")
+	db, net := graphtest.NewVertex("db"), graphtest.NewVertex("net")
+	graphtest.Connect(net, db, "", "dependency")
+	graphtest.Connect(db, app, "", "dependency")
+	graphtest.Connect(app, comp, "", "parent")
+	g := graphtest.NewGraph(comp, app, db, net)
+
+	// Considering every edge mixes the parent edge in with the dependencies.
+	assert.Equal(t, []string{"comp"}, labels(graph.Leaves(g, nil)))
+	assert.Equal(t, []string{"net"}, labels(graph.Orphans(g, nil)))
+
+	// Following only dependency edges, and with the direction reversed, Orphans are the resources that depend on
+	// nothing and Leaves are those that nothing depends on.
+	dependencies := func(e graph.Edge) bool { return e.Color() == "dependency" }
+	assert.Equal(t, []string{"comp", "net"}, labels(graph.Orphans(g, dependencies)))
+	assert.Equal(t, []string{"comp", "app"}, labels(graph.Leaves(g, dependencies)))
+}
+
+func TestDependencies(t *testing.T) {
+	t.Parallel()
+
+	// app -> service -> db -> net, with app also depending on db directly.
+	app, service := graphtest.NewVertex("app"), graphtest.NewVertex("service")
+	db, net := graphtest.NewVertex("db"), graphtest.NewVertex("net")
+	graphtest.Connect(app, service, "", "")
+	graphtest.Connect(app, db, "", "")
+	graphtest.Connect(service, db, "", "")
+	graphtest.Connect(db, net, "", "")
+
+	assert.Equal(t, []string{"net", "db", "service"}, labels(graph.Dependencies(app, nil)))
+	assert.Equal(t, []string{"net", "db"}, labels(graph.Dependencies(service, nil)))
+	assert.Empty(t, graph.Dependencies(net, nil))
+}
+
+func TestDependenciesCycle(t *testing.T) {
+	t.Parallel()
+
+	// a -> b, b -> c, c -> b.  Each vertex still appears once, but c comes before b even though c depends on b.
+	a, b, c := graphtest.NewVertex("a"), graphtest.NewVertex("b"), graphtest.NewVertex("c")
+	graphtest.Connect(a, b, "", "")
+	graphtest.Connect(b, c, "", "")
+	graphtest.Connect(c, b, "", "")
+
+	assert.Equal(t, []string{"c", "b"}, labels(graph.Dependencies(a, nil)))
+
+	// A cycle back to the starting vertex doesn't include it.
+	graphtest.Connect(c, a, "", "")
+	assert.Equal(t, []string{"c", "b"}, labels(graph.Dependencies(a, nil)))
+}
+
+func TestDependenciesFollow(t *testing.T) {
+	t.Parallel()
+
+	// app -> db -> net as dependencies, plus a parent edge from app to comp that should not be followed.
+	app, db, net, comp := graphtest.NewVertex("app"), graphtest.NewVertex("db"), graphtest.NewVertex("net"),
+		graphtest.NewVertex("comp")
+	graphtest.Connect(app, db, "", "dependency")
+	graphtest.Connect(db, net, "", "dependency")
+	graphtest.Connect(app, comp, "", "parent")
+
+	dependencies := func(e graph.Edge) bool { return e.Color() == "dependency" }
+	assert.Equal(t, []string{"net", "db"}, labels(graph.Dependencies(app, dependencies)))
+	assert.Equal(t, []string{"net", "db", "comp"}, labels(graph.Dependencies(app, nil)))
+}
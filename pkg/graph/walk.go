@@ -0,0 +1,71 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph
+
+import (
+	"strconv"
+)
+
+// Walk visits every vertex reachable from the graph's roots exactly once, breadth-first, in the order in which the
+// graph reports its root and outgoing edges.  The walk is only deterministic if the graph reports those edges in a
+// fixed order; a graph that builds them by ranging over a map, for instance, will be walked differently each time.
+// Walking stops at, and returns, the first error returned by visit.
+func Walk(g Graph, visit func(v Vertex) error) error {
+	// Initialize the frontier with the unique targets of the root edges.
+	var frontier []Vertex
+	queued := make(map[Vertex]bool)
+	for _, root := range g.Roots() {
+		if to := root.To(); !queued[to] {
+			queued[to] = true
+			frontier = append(frontier, to)
+		}
+	}
+
+	// Now, until the frontier is empty, visit the head and enqueue any dependencies we haven't seen yet.
+	for len(frontier) > 0 {
+		v := frontier[0]
+		frontier = frontier[1:]
+		if err := visit(v); err != nil {
+			return err
+		}
+		for _, out := range v.Outs() {
+			if to := out.To(); !queued[to] {
+				queued[to] = true
+				frontier = append(frontier, to)
+			}
+		}
+	}
+	return nil
+}
+
+// VertexIDs assigns each vertex an ID of the form "Resource<N>", numbered in the order in which IDs are first
+// requested.  Combined with Walk, IDs are stable across runs under the same condition as the walk itself: the graph
+// must report its root and outgoing edges in a fixed order.  The zero value is ready to use.
+type VertexIDs struct {
+	ids map[Vertex]string
+}
+
+// ID returns the ID for the given vertex, allocating a fresh one if this is the first request for it.
+func (ids *VertexIDs) ID(v Vertex) string {
+	if id, has := ids.ids[v]; has {
+		return id
+	}
+	if ids.ids == nil {
+		ids.ids = make(map[Vertex]string)
+	}
+	id := "Resource" + strconv.Itoa(len(ids.ids))
+	ids.ids[v] = id
+	return id
+}
@@ -0,0 +1,109 @@
+// Copyright 2016-2023, Pulumi Corporation.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package graph_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/pulumi/pulumi/pkg/v3/graph"
+	"github.com/pulumi/pulumi/pkg/v3/graph/internal/graphtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func labels(vs []graph.Vertex) []string {
+	var ls []string
+	for _, v := range vs {
+		ls = append(ls, v.Label())
+	}
+	return ls
+}
+
+func TestWalk(t *testing.T) {
+	t.Parallel()
+
+	// a -> b, a -> c, b -> d, c -> d, with a listed twice among the roots.
+	a, b, c, d := graphtest.NewVertex("a"), graphtest.NewVertex("b"), graphtest.NewVertex("c"), graphtest.NewVertex("d")
+	graphtest.Connect(a, b, "", "")
+	graphtest.Connect(a, c, "", "")
+	graphtest.Connect(b, d, "", "")
+	graphtest.Connect(c, d, "", "")
+	g := graphtest.NewGraph(a, a)
+
+	var visited []graph.Vertex
+	err := graph.Walk(g, func(v graph.Vertex) error {
+		visited = append(visited, v)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, labels(visited))
+}
+
+func TestWalkMultipleRoots(t *testing.T) {
+	t.Parallel()
+
+	// Roots c, a and b, where c -> b and a -> d.  Roots are visited in the order given, before anything found only
+	// through their edges, and b is not visited a second time when reached from c.
+	a, b, c, d := graphtest.NewVertex("a"), graphtest.NewVertex("b"), graphtest.NewVertex("c"), graphtest.NewVertex("d")
+	graphtest.Connect(c, b, "", "")
+	graphtest.Connect(a, d, "", "")
+
+	var visited []graph.Vertex
+	err := graph.Walk(graphtest.NewGraph(c, a, b), func(v graph.Vertex) error {
+		visited = append(visited, v)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c", "a", "b", "d"}, labels(visited))
+}
+
+func TestWalkStopsOnError(t *testing.T) {
+	t.Parallel()
+
+	a, b := graphtest.NewVertex("a"), graphtest.NewVertex("b")
+	graphtest.Connect(a, b, "", "")
+
+	stop := errors.New("stop")
+	var visited []graph.Vertex
+	err := graph.Walk(graphtest.NewGraph(a), func(v graph.Vertex) error {
+		visited = append(visited, v)
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, []string{"a"}, labels(visited))
+}
+
+func TestVertexIDs(t *testing.T) {
+	t.Parallel()
+
+	// a -> b, a -> c, b -> d, c -> d.
+	a, b, c, d := graphtest.NewVertex("a"), graphtest.NewVertex("b"), graphtest.NewVertex("c"), graphtest.NewVertex("d")
+	graphtest.Connect(a, b, "", "")
+	graphtest.Connect(a, c, "", "")
+	graphtest.Connect(b, d, "", "")
+	graphtest.Connect(c, d, "", "")
+
+	var ids graph.VertexIDs
+	var idList []string
+	err := graph.Walk(graphtest.NewGraph(a), func(v graph.Vertex) error {
+		idList = append(idList, ids.ID(v))
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Resource0", "Resource1", "Resource2", "Resource3"}, idList)
+
+	// Asking again for a vertex's ID returns the one it was first given.
+	assert.Equal(t, "Resource2", ids.ID(c))
+}